@@ -0,0 +1,15 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/models/migrations/base"
+	"code.gitea.io/gitea/models/migrations/v1_23"
+)
+
+// migrations is the list of all database schema migrations, applied in order
+// the first time Gitea starts against a given database version.
+var migrations = []*base.Migration{
+	base.NewMigration("Add pinned_repo table", v1_23.AddPinnedRepoTable),
+}
@@ -0,0 +1,21 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_23 //nolint
+
+import (
+	"xorm.io/xorm"
+)
+
+// AddPinnedRepoTable adds the table backing an organization's pinned
+// repositories, as surfaced on the org home page.
+func AddPinnedRepoTable(x *xorm.Engine) error {
+	type PinnedRepo struct {
+		ID     int64 `xorm:"pk autoincr"`
+		OrgID  int64 `xorm:"UNIQUE(s) INDEX"`
+		RepoID int64 `xorm:"UNIQUE(s)"`
+		Weight int
+	}
+
+	return x.Sync(new(PinnedRepo))
+}
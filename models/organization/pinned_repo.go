@@ -0,0 +1,118 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	unit_model "code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+
+	"xorm.io/builder"
+)
+
+// PinnedRepo represents a repository pinned to an organization's home page by
+// one of its owners. Weight controls display order: lower weights sort first.
+type PinnedRepo struct {
+	ID     int64 `xorm:"pk autoincr"`
+	OrgID  int64 `xorm:"UNIQUE(s) INDEX"`
+	RepoID int64 `xorm:"UNIQUE(s)"`
+	Weight int
+}
+
+func init() {
+	db.RegisterModel(new(PinnedRepo))
+}
+
+// ErrTooManyPinnedRepos is returned by PinRepo once an organization has
+// reached setting.UI.User.PinnedRepoMaxNum pinned repositories.
+var ErrTooManyPinnedRepos = fmt.Errorf("organization has reached the maximum number of pinned repositories")
+
+// PinRepo pins repoID to orgID's home page, appending it after any already
+// pinned repositories. Pinning an already-pinned repository is a no-op. It
+// returns ErrTooManyPinnedRepos once the configured maximum is reached. The
+// pinned check, count check, max-weight read, and insert all run inside a
+// single transaction so concurrent pin requests for the same org can't blow
+// past the configured maximum or collide on weight.
+func PinRepo(ctx context.Context, orgID, repoID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		pinned, err := IsRepoPinned(ctx, orgID, repoID)
+		if err != nil {
+			return err
+		}
+		if pinned {
+			return nil
+		}
+
+		count, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Count(new(PinnedRepo))
+		if err != nil {
+			return err
+		}
+		if int(count) >= setting.UI.User.PinnedRepoMaxNum {
+			return ErrTooManyPinnedRepos
+		}
+
+		var maxWeight int
+		if _, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Select("COALESCE(MAX(weight), -1)").Table("pinned_repo").Get(&maxWeight); err != nil {
+			return err
+		}
+
+		_, err = db.GetEngine(ctx).Insert(&PinnedRepo{
+			OrgID:  orgID,
+			RepoID: repoID,
+			Weight: maxWeight + 1,
+		})
+		return err
+	})
+}
+
+// UnpinRepo removes repoID from orgID's pinned repositories.
+func UnpinRepo(ctx context.Context, orgID, repoID int64) error {
+	_, err := db.GetEngine(ctx).Delete(&PinnedRepo{OrgID: orgID, RepoID: repoID})
+	return err
+}
+
+// IsRepoPinned reports whether repoID is currently pinned for orgID.
+func IsRepoPinned(ctx context.Context, orgID, repoID int64) (bool, error) {
+	return db.GetEngine(ctx).Where("org_id = ? AND repo_id = ?", orgID, repoID).Exist(new(PinnedRepo))
+}
+
+// ReorderPinnedRepos persists a new display order for orgID's pinned
+// repositories, assigning weights in the order repoIDs are given.
+func ReorderPinnedRepos(ctx context.Context, orgID int64, repoIDs []int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for weight, repoID := range repoIDs {
+			if _, err := db.GetEngine(ctx).
+				Where("org_id = ? AND repo_id = ?", orgID, repoID).
+				Cols("weight").
+				Update(&PinnedRepo{Weight: weight}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetPinnedRepos returns orgID's pinned repositories, in display order,
+// skipping any repository the given actor cannot see. The join with
+// repository happens in a single query rather than one lookup per pinned repo.
+func GetPinnedRepos(ctx context.Context, orgID int64, actor *user_model.User) ([]*repo_model.Repository, error) {
+	repos := make([]*repo_model.Repository, 0, setting.UI.User.PinnedRepoMaxNum)
+
+	cond := builder.In("repository.id",
+		builder.Select("repo_id").From("pinned_repo").Where(builder.Eq{"org_id": orgID}),
+	).And(repo_model.AccessibleRepositoryCondition(actor, unit_model.TypeInvalid))
+
+	err := db.GetEngine(ctx).
+		Table("repository").
+		Join("INNER", "pinned_repo", "pinned_repo.repo_id = repository.id").
+		Where(cond).
+		OrderBy("pinned_repo.weight ASC").
+		Find(&repos)
+	return repos, err
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pinOrgPublicID = int64(10002)
+
+func TestPinRepo(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10003})
+
+	assert.NoError(t, organization.PinRepo(db.DefaultContext, pinOrgPublicID, repo.ID))
+
+	pinned, err := organization.IsRepoPinned(db.DefaultContext, pinOrgPublicID, repo.ID)
+	assert.NoError(t, err)
+	assert.True(t, pinned)
+
+	// Pinning an already-pinned repo is a no-op, not a duplicate-row error.
+	assert.NoError(t, organization.PinRepo(db.DefaultContext, pinOrgPublicID, repo.ID))
+}
+
+func TestPinRepo_TooMany(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	oldMax := setting.UI.User.PinnedRepoMaxNum
+	setting.UI.User.PinnedRepoMaxNum = 1
+	defer func() { setting.UI.User.PinnedRepoMaxNum = oldMax }()
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10003})
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10004})
+
+	assert.NoError(t, organization.PinRepo(db.DefaultContext, pinOrgPublicID, repo1.ID))
+	assert.ErrorIs(t, organization.PinRepo(db.DefaultContext, pinOrgPublicID, repo2.ID), organization.ErrTooManyPinnedRepos)
+}
+
+func TestUnpinRepo(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10003})
+
+	assert.NoError(t, organization.PinRepo(db.DefaultContext, pinOrgPublicID, repo.ID))
+	assert.NoError(t, organization.UnpinRepo(db.DefaultContext, pinOrgPublicID, repo.ID))
+
+	pinned, err := organization.IsRepoPinned(db.DefaultContext, pinOrgPublicID, repo.ID)
+	assert.NoError(t, err)
+	assert.False(t, pinned)
+}
+
+func TestGetPinnedRepos_ReorderChangesDisplayOrder(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10003})
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10004})
+
+	assert.NoError(t, organization.PinRepo(db.DefaultContext, pinOrgPublicID, repo1.ID))
+	assert.NoError(t, organization.PinRepo(db.DefaultContext, pinOrgPublicID, repo2.ID))
+	assert.NoError(t, organization.ReorderPinnedRepos(db.DefaultContext, pinOrgPublicID, []int64{repo2.ID, repo1.ID}))
+
+	repos, err := organization.GetPinnedRepos(db.DefaultContext, pinOrgPublicID, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, repos, 2) {
+		assert.Equal(t, repo2.ID, repos[0].ID)
+		assert.Equal(t, repo1.ID, repos[1].ID)
+	}
+}
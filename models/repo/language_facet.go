@@ -0,0 +1,42 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+)
+
+// LanguageFacet is a single entry in a per-language repository count facet,
+// computed alongside a repository search.
+type LanguageFacet struct {
+	Name  string
+	Count int64
+}
+
+// maxLanguageFacets caps how many distinct languages CountLanguagesInSearch
+// returns.
+const maxLanguageFacets = 20
+
+// CountLanguagesInSearch computes, for the given search scope, how many
+// repositories fall under each primary language (as recorded in
+// language_stat, the same table the repository language filter reads from),
+// returning the top maxLanguageFacets by count. It runs as a single aggregate
+// query rather than one query per language.
+func CountLanguagesInSearch(ctx context.Context, opts *SearchRepoOptions) ([]*LanguageFacet, error) {
+	cond := SearchRepositoryCondition(opts)
+
+	facets := make([]*LanguageFacet, 0, maxLanguageFacets)
+	err := db.GetEngine(ctx).
+		Table("repository").
+		Join("INNER", "language_stat", "language_stat.repo_id = repository.id AND language_stat.is_primary = ?", true).
+		Select("language_stat.language AS name, COUNT(*) AS count").
+		Where(cond).
+		GroupBy("language_stat.language").
+		OrderBy("count DESC").
+		Limit(maxLanguageFacets).
+		Find(&facets)
+	return facets, err
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountLanguagesInSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	facets, err := repo_model.CountLanguagesInSearch(db.DefaultContext, &repo_model.SearchRepoOptions{
+		OwnerID: 10002,
+		Private: true,
+	})
+	assert.NoError(t, err)
+
+	// Only the is_primary=true rows count, so each of repo-a and repo-b
+	// contributes one Go hit; the non-primary JavaScript row on repo-b is
+	// excluded.
+	if assert.Len(t, facets, 1) {
+		assert.Equal(t, "Go", facets[0].Name)
+		assert.EqualValues(t, 2, facets[0].Count)
+	}
+}
+
+func TestSearchRepository_FiltersByLanguage(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	repos, count, err := repo_model.SearchRepository(db.DefaultContext, &repo_model.SearchRepoOptions{
+		OwnerID:   10002,
+		Private:   true,
+		Languages: []string{"JavaScript"},
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+	assert.Empty(t, repos)
+
+	repos, count, err = repo_model.SearchRepository(db.DefaultContext, &repo_model.SearchRepoOptions{
+		OwnerID:   10002,
+		Private:   true,
+		Languages: []string{"Go"},
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	assert.Len(t, repos, 2)
+}
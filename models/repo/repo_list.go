@@ -0,0 +1,82 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"xorm.io/builder"
+)
+
+// SearchRepoOptions holds the search criteria accepted by SearchRepository.
+type SearchRepoOptions struct {
+	db.ListOptions
+	Keyword string
+	OwnerID int64
+	OrderBy db.SearchOrderBy
+	Private bool
+	Actor   *user_model.User
+	// Languages restricts results to repositories whose primary language (per
+	// language_stat) is one of these. Empty means no language restriction.
+	Languages          []string
+	IncludeDescription bool
+}
+
+// SearchRepositoryCondition builds the SQL condition matching opts, shared by
+// SearchRepository and CountLanguagesInSearch so both see the same scope.
+func SearchRepositoryCondition(opts *SearchRepoOptions) builder.Cond {
+	cond := builder.NewCond()
+
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"repository.owner_id": opts.OwnerID})
+	}
+
+	if opts.Keyword != "" {
+		lowerKeyword := strings.ToLower(opts.Keyword)
+		keywordCond := builder.NewCond().Or(builder.Like{"LOWER(repository.lower_name)", lowerKeyword})
+		if opts.IncludeDescription {
+			keywordCond = keywordCond.Or(builder.Like{"LOWER(repository.description)", lowerKeyword})
+		}
+		cond = cond.And(keywordCond)
+	}
+
+	if len(opts.Languages) > 0 {
+		cond = cond.And(builder.In("repository.id",
+			builder.Select("language_stat.repo_id").From("language_stat").
+				Where(builder.Eq{"language_stat.is_primary": true}).
+				And(builder.In("language_stat.language", opts.Languages)),
+		))
+	}
+
+	if !opts.Private {
+		cond = cond.And(builder.Eq{"repository.is_private": false})
+	}
+
+	cond = cond.And(AccessibleRepositoryCondition(opts.Actor, unit.TypeInvalid))
+
+	return cond
+}
+
+// SearchRepository returns the repositories matching opts, paginated, along
+// with the total match count.
+func SearchRepository(ctx context.Context, opts *SearchRepoOptions) ([]*Repository, int64, error) {
+	cond := SearchRepositoryCondition(opts)
+
+	sess := db.GetEngine(ctx).Where(cond)
+	if opts.OrderBy != "" {
+		sess = sess.OrderBy(opts.OrderBy.String())
+	}
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, opts)
+	}
+
+	repos := make([]*Repository, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&repos)
+	return repos, count, err
+}
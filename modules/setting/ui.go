@@ -0,0 +1,24 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+// UI settings controlling how repositories are displayed and paginated.
+var UI = struct {
+	SearchRepoDescription bool
+	RepoSearchPagingNum   int
+	User                  struct {
+		RepoPagingNum    int
+		PinnedRepoMaxNum int
+	}
+}{
+	SearchRepoDescription: true,
+	RepoSearchPagingNum:   10,
+	User: struct {
+		RepoPagingNum    int
+		PinnedRepoMaxNum int
+	}{
+		RepoPagingNum:    15,
+		PinnedRepoMaxNum: 6,
+	},
+}
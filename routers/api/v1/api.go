@@ -0,0 +1,24 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"code.gitea.io/gitea/modules/context"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/org"
+)
+
+// RegisterOrgPinnedRepoRoutes registers the pinned-repository endpoints under
+// /orgs/{org}/pinned_repos.
+func RegisterOrgPinnedRepoRoutes(m *web.Router) {
+	m.Group("/orgs/{org}/pinned_repos", func() {
+		m.Get("", org.ListPinnedRepos)
+		m.Post("/reorder", reqOrgOwnership(), bind(api.ReorderPinnedReposOption{}), org.ReorderPinnedRepos)
+		m.Group("/{repo}", func() {
+			m.Put("", reqOrgOwnership(), org.PinRepo)
+			m.Delete("", reqOrgOwnership(), org.UnpinRepo)
+		}, repoAssignment())
+	}, context.OrgAssignment(), reqToken())
+}
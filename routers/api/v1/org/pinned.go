@@ -0,0 +1,159 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// ListPinnedRepos returns the repositories pinned to an organization's home page.
+func ListPinnedRepos(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/pinned_repos organization orgListPinnedRepos
+	// ---
+	// summary: List an organization's pinned repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepositoryList"
+	repos, err := organization.GetPinnedRepos(ctx, ctx.Org.Organization.ID, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetPinnedRepos", err)
+		return
+	}
+
+	apiRepos := make([]*api.Repository, len(repos))
+	for i, repo := range repos {
+		permission, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
+			return
+		}
+		apiRepos[i] = convert.ToRepo(ctx, repo, permission)
+	}
+
+	ctx.JSON(http.StatusOK, apiRepos)
+}
+
+// PinRepo pins a repository to the organization's home page.
+func PinRepo(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/pinned_repos/{repo} organization orgPinRepo
+	// ---
+	// summary: Pin a repository to an organization's home page
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository to pin
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	if !ctx.Org.Organization.IsOwnedBy(ctx.Doer.ID) && !ctx.Doer.IsAdmin {
+		ctx.Error(http.StatusForbidden, "PinRepo", "must be an organization owner")
+		return
+	}
+
+	if err := organization.PinRepo(ctx, ctx.Org.Organization.ID, ctx.Repo.Repository.ID); err != nil {
+		if errors.Is(err, organization.ErrTooManyPinnedRepos) {
+			ctx.Error(http.StatusUnprocessableEntity, "PinRepo", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "PinRepo", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// UnpinRepo unpins a repository from the organization's home page.
+func UnpinRepo(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/pinned_repos/{repo} organization orgUnpinRepo
+	// ---
+	// summary: Unpin a repository from an organization's home page
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository to unpin
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	if !ctx.Org.Organization.IsOwnedBy(ctx.Doer.ID) && !ctx.Doer.IsAdmin {
+		ctx.Error(http.StatusForbidden, "UnpinRepo", "must be an organization owner")
+		return
+	}
+
+	if err := organization.UnpinRepo(ctx, ctx.Org.Organization.ID, ctx.Repo.Repository.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UnpinRepo", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ReorderPinnedRepos updates the display order of an organization's pinned
+// repositories.
+func ReorderPinnedRepos(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/pinned_repos/reorder organization orgReorderPinnedRepos
+	// ---
+	// summary: Update the display order of an organization's pinned repositories
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ReorderPinnedReposOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	if !ctx.Org.Organization.IsOwnedBy(ctx.Doer.ID) && !ctx.Doer.IsAdmin {
+		ctx.Error(http.StatusForbidden, "ReorderPinnedRepos", "must be an organization owner")
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.ReorderPinnedReposOption)
+	if err := organization.ReorderPinnedRepos(ctx, ctx.Org.Organization.ID, form.RepoIDs); err != nil {
+		ctx.Error(http.StatusInternalServerError, "ReorderPinnedRepos", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// codeLanguageFacet is a single entry in the language filter sidebar rendered
+// on the code-search tab, pairing a code_indexer.SearchResultLanguages count
+// with whether the viewer currently has that language selected.
+type codeLanguageFacet struct {
+	Name     string
+	Color    string
+	Count    int
+	Selected bool
+}
+
+// renderOrgCodeSearch renders the `?type=code&q=…` tab on the organization
+// home page, searching only across repositories owned by org that ctx.Doer
+// can see. Callers must check setting.Indexer.RepoIndexerEnabled before
+// calling this; the tab is hidden entirely when the indexer is disabled.
+func renderOrgCodeSearch(ctx *context.Context, org *organization.Organization) {
+	keyword := ctx.FormTrim("q")
+	language := ctx.FormTrim("language")
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	repos, _, err := repo_model.SearchRepository(ctx, &repo_model.SearchRepoOptions{
+		OwnerID: org.ID,
+		Private: ctx.IsSigned,
+		Actor:   ctx.Doer,
+	})
+	if err != nil {
+		ctx.ServerError("SearchRepository", err)
+		return
+	}
+
+	repoIDs := make([]int64, len(repos))
+	for i, repo := range repos {
+		repoIDs[i] = repo.ID
+	}
+
+	var (
+		total   int
+		results []*code_indexer.Result
+		facets  []*code_indexer.SearchResultLanguages
+	)
+	if len(repoIDs) > 0 {
+		total, results, facets, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
+			RepoIDs:  repoIDs,
+			Keyword:  keyword,
+			Language: language,
+			Paginator: &db.ListOptions{
+				Page:     page,
+				PageSize: setting.UI.RepoSearchPagingNum,
+			},
+		})
+		if err != nil {
+			ctx.ServerError("PerformSearch", err)
+			return
+		}
+	}
+
+	if err := loadOrgHomeSharedData(ctx, org); err != nil {
+		ctx.ServerError("loadOrgHomeSharedData", err)
+		return
+	}
+
+	codeFacets := make([]*codeLanguageFacet, len(facets))
+	for i, f := range facets {
+		codeFacets[i] = &codeLanguageFacet{
+			Name:     f.Language,
+			Color:    f.Color,
+			Count:    f.Count,
+			Selected: f.Language == language,
+		}
+	}
+
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["Language"] = language
+	ctx.Data["PageIsViewCode"] = true
+	ctx.Data["SearchResults"] = results
+	ctx.Data["SearchResultLanguages"] = codeFacets
+	ctx.Data["Total"] = total
+
+	pager := context.NewPagination(total, setting.UI.RepoSearchPagingNum, page, 5)
+	pager.SetDefaultParams(ctx)
+	pager.AddParam(ctx, "language", "Language")
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplOrgHome)
+}
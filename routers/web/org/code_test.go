@@ -0,0 +1,32 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/contexttest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRenderOrgCodeSearch_NoRepos covers an org with no repositories: the
+// indexer must not be queried (there's nothing to search), and the page
+// should still render with empty results rather than erroring out.
+func TestRenderOrgCodeSearch_NoRepos(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	ctx, _ := contexttest.MockContext(t, "org3?type=code")
+	ctx.SetParams(":username", "org3")
+	org := &organization.Organization{ID: 3}
+
+	assert.NotPanics(t, func() {
+		renderOrgCodeSearch(ctx, org)
+	})
+	assert.Equal(t, http.StatusOK, ctx.Resp.Status())
+	assert.Empty(t, ctx.Data["SearchResults"])
+}
@@ -5,24 +5,139 @@ package org
 
 import (
 	"code.gitea.io/gitea/modules/log"
+	"fmt"
 	"net/http"
 	"strings"
-	"sync"
 
+	access_model "code.gitea.io/gitea/models/perm/access"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/organization"
 	repo_model "code.gitea.io/gitea/models/repo"
+	unit_model "code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/markup/markdown"
 	"code.gitea.io/gitea/modules/setting"
+
+	"golang.org/x/sync/errgroup"
 )
 
 const (
 	tplOrgHome base.TplName = "org/home"
 )
 
+// languageFacet is a single entry in the language filter sidebar rendered on
+// the org home page, pairing a repo_model.LanguageFacet count with whether
+// the viewer currently has that language selected. Link toggles that single
+// language on or off while preserving every other active query param.
+type languageFacet struct {
+	Name     string
+	Count    int64
+	Selected bool
+	Link     string
+}
+
+// toggleLanguage returns languages with name added if not already present,
+// or removed if it is, leaving the relative order of the rest unchanged.
+func toggleLanguage(languages []string, name string) []string {
+	toggled := make([]string, 0, len(languages)+1)
+	found := false
+	for _, l := range languages {
+		if l == name {
+			found = true
+			continue
+		}
+		toggled = append(toggled, l)
+	}
+	if !found {
+		toggled = append(toggled, name)
+	}
+	return toggled
+}
+
+// languageFacetLink builds the repo-list URL for toggling name in the
+// viewer's current language selection, preserving every other active query
+// param (q, sort, page, …) instead of overwriting the whole query string.
+func languageFacetLink(ctx *context.Context, languages []string, name string) string {
+	query := ctx.Req.URL.Query()
+	toggled := toggleLanguage(languages, name)
+	if len(toggled) == 0 {
+		query.Del("language")
+	} else {
+		query.Set("language", strings.Join(toggled, ","))
+	}
+	query.Del("page")
+	return "?" + query.Encode()
+}
+
+// prepareOrgProfileReadme loads the organization's `.profile` repository, if one
+// exists and is readable by the current viewer, and renders its README for
+// display on the org home page. Any failure along the way (repo missing,
+// private and not visible to the viewer, no README present, render error) is
+// logged where appropriate and otherwise falls back silently to the normal
+// repository-list view.
+func prepareOrgProfileReadme(ctx *context.Context, org *organization.Organization) {
+	profileRepo, err := repo_model.GetRepositoryByName(org.ID, ".profile")
+	if err != nil {
+		if !repo_model.IsErrRepoNotExist(err) {
+			log.Error("GetRepositoryByName: %v", err)
+		}
+		return
+	}
+
+	perm, err := access_model.GetUserRepoPermission(ctx, profileRepo, ctx.Doer)
+	if err != nil {
+		log.Error("GetUserRepoPermission: %v", err)
+		return
+	}
+	if !perm.CanRead(unit_model.TypeCode) {
+		return
+	}
+
+	gitRepo, err := git.OpenRepository(ctx, profileRepo.RepoPath())
+	if err != nil {
+		log.Error("OpenRepository: %v", err)
+		return
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(profileRepo.DefaultBranch)
+	if err != nil {
+		log.Error("GetBranchCommit: %v", err)
+		return
+	}
+
+	entry, err := commit.GetTreeEntryByPath("README.md")
+	if err != nil {
+		entry, err = commit.GetTreeEntryByPath("profile/README.md")
+		if err != nil {
+			return
+		}
+	}
+
+	blob := entry.Blob()
+	content, err := blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
+	if err != nil {
+		log.Error("GetBlobContent: %v", err)
+		return
+	}
+
+	rendered, err := markdown.RenderString(&markup.RenderContext{
+		Ctx:       ctx,
+		URLPrefix: profileRepo.Link() + "/raw/branch/" + profileRepo.DefaultBranch,
+		Metas:     map[string]string{"mode": "document"},
+		GitRepo:   gitRepo,
+	}, content)
+	if err != nil {
+		log.Error("RenderString: %v", err)
+		return
+	}
+
+	ctx.Data["ProfileReadme"] = rendered
+}
+
 // Home show organization home page
 func Home(ctx *context.Context) {
 	uname := ctx.Params(":username")
@@ -56,6 +171,21 @@ func Home(ctx *context.Context) {
 		ctx.Data["RenderedDescription"] = desc
 	}
 
+	ctx.Data["CodeIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	if setting.Indexer.RepoIndexerEnabled && ctx.FormString("type") == "code" {
+		renderOrgCodeSearch(ctx, org)
+		return
+	}
+
+	prepareOrgProfileReadme(ctx, org)
+
+	pinnedRepos, err := organization.GetPinnedRepos(ctx, org.ID, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetPinnedRepos", err)
+		return
+	}
+	ctx.Data["PinnedRepos"] = pinnedRepos
+
 	var orderBy db.SearchOrderBy
 	ctx.Data["SortType"] = ctx.FormString("sort")
 	switch ctx.FormString("sort") {
@@ -87,8 +217,13 @@ func Home(ctx *context.Context) {
 	keyword := ctx.FormTrim("q")
 	ctx.Data["Keyword"] = keyword
 
-	language := ctx.FormTrim("language")
-	ctx.Data["Language"] = language
+	var languages []string
+	for _, l := range strings.Split(ctx.FormTrim("language"), ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			languages = append(languages, l)
+		}
+	}
+	ctx.Data["Language"] = strings.Join(languages, ",")
 
 	page := ctx.FormInt("page")
 	if page <= 0 {
@@ -98,7 +233,6 @@ func Home(ctx *context.Context) {
 	var (
 		repos []*repo_model.Repository
 		count int64
-		err   error
 	)
 	repos, count, err = repo_model.SearchRepository(ctx, &repo_model.SearchRepoOptions{
 		ListOptions: db.ListOptions{
@@ -110,7 +244,7 @@ func Home(ctx *context.Context) {
 		OrderBy:            orderBy,
 		Private:            ctx.IsSigned,
 		Actor:              ctx.Doer,
-		Language:           language,
+		Languages:          languages,
 		IncludeDescription: setting.UI.SearchRepoDescription,
 	})
 	if err != nil {
@@ -118,30 +252,35 @@ func Home(ctx *context.Context) {
 		return
 	}
 
-	opts := &organization.FindOrgMembersOpts{
-		OrgID:       org.ID,
-		PublicOnly:  true,
-		ListOptions: db.ListOptions{Page: 1, PageSize: 25},
+	languageFacets, err := repo_model.CountLanguagesInSearch(ctx, &repo_model.SearchRepoOptions{
+		Keyword: keyword,
+		OwnerID: org.ID,
+		Private: ctx.IsSigned,
+		Actor:   ctx.Doer,
+	})
+	if err != nil {
+		ctx.ServerError("CountLanguagesInSearch", err)
+		return
 	}
 
-	if ctx.Doer != nil {
-		isMember, err := org.IsOrgMember(ctx.Doer.ID)
-		if err != nil {
-			ctx.Error(http.StatusInternalServerError, "IsOrgMember")
-			return
-		}
-		opts.PublicOnly = !isMember && !ctx.Doer.IsAdmin
+	selectedLanguages := make(map[string]bool, len(languages))
+	for _, l := range languages {
+		selectedLanguages[l] = true
 	}
 
-	members, _, err := organization.FindOrgMembers(opts)
-	if err != nil {
-		ctx.ServerError("FindOrgMembers", err)
-		return
+	facets := make([]*languageFacet, len(languageFacets))
+	for i, f := range languageFacets {
+		facets[i] = &languageFacet{
+			Name:     f.Name,
+			Count:    f.Count,
+			Selected: selectedLanguages[f.Name],
+			Link:     languageFacetLink(ctx, languages, f.Name),
+		}
 	}
+	ctx.Data["LanguageFacets"] = facets
 
-	membersCount, err := organization.CountOrgMembers(opts)
-	if err != nil {
-		ctx.ServerError("CountOrgMembers", err)
+	if err := loadOrgHomeSharedData(ctx, org); err != nil {
+		ctx.ServerError("loadOrgHomeSharedData", err)
 		return
 	}
 
@@ -150,51 +289,43 @@ func Home(ctx *context.Context) {
 		repoIds[i] = repo.ID
 	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	var watchedRepoIdsMap map[int64]bool
-	go func() {
-		defer wg.Done()
-		watchedRepoIds, err := repo_model.FilterWatchedRepoIds(ctx, ctx.Doer.ID, repoIds)
-		if err != nil {
-			log.Error("Failed getting watched repositories ids: %w", err)
-			return
-		}
-		if len(watchedRepoIds) == 0 {
-			return
-		}
-		watchedRepoIdsMap = make(map[int64]bool, len(watchedRepoIds))
-		for _, id := range watchedRepoIds {
-			watchedRepoIdsMap[id] = true
-		}
-	}()
+	var watchedRepoIdsMap, starredRepoIdsMap map[int64]bool
+	if ctx.Doer != nil {
+		doerID := ctx.Doer.ID
+		g, groupCtx := errgroup.WithContext(ctx)
 
-	wg.Add(1)
-	var starredRepoIdsMap map[int64]bool
-	go func() {
-		defer wg.Done()
-		starredRepoIds, err := repo_model.FilterStarredRepoIds(ctx, ctx.Doer.ID, repoIds)
-		if err != nil {
-			log.Error("Failed getting starred repositories ids: %w", err)
-			return
-		}
-		if len(starredRepoIds) == 0 {
+		g.Go(func() error {
+			watchedRepoIds, err := repo_model.FilterWatchedRepoIds(groupCtx, doerID, repoIds)
+			if err != nil {
+				return fmt.Errorf("FilterWatchedRepoIds: %w", err)
+			}
+			watchedRepoIdsMap = make(map[int64]bool, len(watchedRepoIds))
+			for _, id := range watchedRepoIds {
+				watchedRepoIdsMap[id] = true
+			}
+			return nil
+		})
+
+		g.Go(func() error {
+			starredRepoIds, err := repo_model.FilterStarredRepoIds(groupCtx, doerID, repoIds)
+			if err != nil {
+				return fmt.Errorf("FilterStarredRepoIds: %w", err)
+			}
+			starredRepoIdsMap = make(map[int64]bool, len(starredRepoIds))
+			for _, id := range starredRepoIds {
+				starredRepoIdsMap[id] = true
+			}
+			return nil
+		})
+
+		if err := g.Wait(); err != nil {
+			ctx.ServerError("Home", err)
 			return
 		}
-		starredRepoIdsMap = make(map[int64]bool, len(starredRepoIds))
-		for _, id := range starredRepoIds {
-			starredRepoIdsMap[id] = true
-		}
-	}()
-
-	wg.Wait()
+	}
 
-	ctx.Data["Owner"] = org
 	ctx.Data["Repos"] = repos
 	ctx.Data["Total"] = count
-	ctx.Data["MembersTotal"] = membersCount
-	ctx.Data["Members"] = members
-	ctx.Data["Teams"] = ctx.Org.Teams
 	ctx.Data["DisableNewPullMirrors"] = setting.Mirror.DisableNewPull
 	ctx.Data["PageIsViewRepositories"] = true
 	ctx.Data["WatchedRepos"] = watchedRepoIdsMap
@@ -204,7 +335,43 @@ func Home(ctx *context.Context) {
 	pager.SetDefaultParams(ctx)
 	pager.AddParam(ctx, "language", "Language")
 	ctx.Data["Page"] = pager
-	ctx.Data["ContextUser"] = ctx.ContextUser
 
 	ctx.HTML(http.StatusOK, tplOrgHome)
 }
+
+// loadOrgHomeSharedData populates the ctx.Data keys shared by every tab on the
+// organization home page (member list/count, the owning org, its teams, and
+// the context user used by the page header), so renderOrgCodeSearch can reuse
+// them alongside Home instead of rendering with a blank header/sidebar.
+func loadOrgHomeSharedData(ctx *context.Context, org *organization.Organization) error {
+	opts := &organization.FindOrgMembersOpts{
+		OrgID:       org.ID,
+		PublicOnly:  true,
+		ListOptions: db.ListOptions{Page: 1, PageSize: 25},
+	}
+
+	if ctx.Doer != nil {
+		isMember, err := org.IsOrgMember(ctx.Doer.ID)
+		if err != nil {
+			return err
+		}
+		opts.PublicOnly = !isMember && !ctx.Doer.IsAdmin
+	}
+
+	members, _, err := organization.FindOrgMembers(opts)
+	if err != nil {
+		return err
+	}
+
+	membersCount, err := organization.CountOrgMembers(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx.Data["Owner"] = org
+	ctx.Data["MembersTotal"] = membersCount
+	ctx.Data["Members"] = members
+	ctx.Data["Teams"] = ctx.Org.Teams
+	ctx.Data["ContextUser"] = ctx.ContextUser
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/contexttest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareOrgProfileReadme_NoProfileRepo(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	ctx, _ := contexttest.MockContext(t, "org3")
+	org := &organization.Organization{ID: 3}
+
+	prepareOrgProfileReadme(ctx, org)
+
+	assert.Nil(t, ctx.Data["ProfileReadme"])
+}
+
+func TestPrepareOrgProfileReadme_PrivateHiddenFromAnonymous(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	ctx, _ := contexttest.MockContext(t, "pinorgprivate")
+	ctx.Doer = nil
+	org := &organization.Organization{ID: 10001}
+
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerID: org.ID, Name: ".profile", IsPrivate: true})
+
+	prepareOrgProfileReadme(ctx, org)
+
+	assert.Nil(t, ctx.Data["ProfileReadme"])
+}
+
+// TestHome_Anonymous is a regression test for a panic when anonymous (Doer ==
+// nil) visitors hit an org home page: the watched/starred lookups used to
+// dereference ctx.Doer.ID unconditionally.
+func TestHome_Anonymous(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	ctx, _ := contexttest.MockContext(t, "org3")
+	ctx.Doer = nil
+	ctx.SetParams(":username", "org3")
+
+	assert.NotPanics(t, func() {
+		Home(ctx)
+	})
+	assert.Equal(t, http.StatusOK, ctx.Resp.Status())
+}
+
+// TestPrepareOrgProfileReadme_NoReadme covers a .profile repo that exists and
+// is readable but has no README.md or profile/README.md at its default
+// branch tip. Its on-disk git repo lives under
+// tests/gitea-repositories-meta/pinorgpublic/.profile.git (copied into the
+// test repo root by unittest.PrepareTestEnv) with a single commit containing
+// only a LICENSE file, so this actually exercises the tree-entry lookup and
+// fallback in prepareOrgProfileReadme rather than short-circuiting on
+// git.OpenRepository.
+func TestPrepareOrgProfileReadme_NoReadme(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	ctx, _ := contexttest.MockContext(t, "pinorgpublic")
+	org := &organization.Organization{ID: 10002}
+
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerID: org.ID, Name: ".profile", IsPrivate: false})
+
+	prepareOrgProfileReadme(ctx, org)
+
+	assert.Nil(t, ctx.Data["ProfileReadme"])
+}
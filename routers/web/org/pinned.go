@@ -0,0 +1,104 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// PinRepo pins a repository to the organization's home page. Only
+// organization owners or site admins may pin/unpin repositories.
+func PinRepo(ctx *context.Context) {
+	if !ctx.Org.IsOwner && !ctx.Doer.IsAdmin {
+		ctx.NotFound("PinRepo", nil)
+		return
+	}
+
+	repo, err := repo_model.GetRepositoryByName(ctx.Org.Organization.ID, ctx.FormString("repo"))
+	if err != nil {
+		ctx.ServerError("GetRepositoryByName", err)
+		return
+	}
+
+	if err := organization.PinRepo(ctx, ctx.Org.Organization.ID, repo.ID); err != nil {
+		if errors.Is(err, organization.ErrTooManyPinnedRepos) {
+			ctx.Flash.Error(ctx.Tr("org.pinned_repo.too_many"))
+		} else {
+			ctx.ServerError("PinRepo", err)
+			return
+		}
+	}
+
+	ctx.Redirect(ctx.Org.Organization.HomeLink())
+}
+
+// UnpinRepo unpins a repository from the organization's home page.
+func UnpinRepo(ctx *context.Context) {
+	if !ctx.Org.IsOwner && !ctx.Doer.IsAdmin {
+		ctx.NotFound("UnpinRepo", nil)
+		return
+	}
+
+	repo, err := repo_model.GetRepositoryByName(ctx.Org.Organization.ID, ctx.FormString("repo"))
+	if err != nil {
+		ctx.ServerError("GetRepositoryByName", err)
+		return
+	}
+
+	if err := organization.UnpinRepo(ctx, ctx.Org.Organization.ID, repo.ID); err != nil {
+		ctx.ServerError("UnpinRepo", err)
+		return
+	}
+
+	ctx.Redirect(ctx.Org.Organization.HomeLink())
+}
+
+// ReorderPinnedRepos updates the display order of an organization's pinned
+// repositories. The new order is submitted as "repos", a comma-separated
+// list of repository IDs, most significant first.
+func ReorderPinnedRepos(ctx *context.Context) {
+	if !ctx.Org.IsOwner && !ctx.Doer.IsAdmin {
+		ctx.NotFound("ReorderPinnedRepos", nil)
+		return
+	}
+
+	repoIDs, err := parsePinnedRepoIDs(ctx.FormString("repos"))
+	if err != nil {
+		ctx.Flash.Error(err.Error())
+		ctx.Redirect(ctx.Org.Organization.HomeLink())
+		return
+	}
+
+	if err := organization.ReorderPinnedRepos(ctx, ctx.Org.Organization.ID, repoIDs); err != nil {
+		ctx.ServerError("ReorderPinnedRepos", err)
+		return
+	}
+
+	ctx.Redirect(ctx.Org.Organization.HomeLink())
+}
+
+// parsePinnedRepoIDs parses a comma-separated list of repository IDs, as
+// submitted by the pinned-repo reorder form.
+func parsePinnedRepoIDs(s string) ([]int64, error) {
+	parts := strings.Split(s, ",")
+	repoIDs := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, errors.New("repos must be a comma-separated list of repository IDs")
+		}
+		repoIDs = append(repoIDs, id)
+	}
+	return repoIDs, nil
+}
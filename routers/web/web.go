@@ -0,0 +1,29 @@
+// Copyright 2024 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package web
+
+import (
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/web/org"
+)
+
+var reqSignIn = context.Toggle(&context.ToggleOptions{SignInRequired: true})
+
+// RegisterOrgPinnedRepoRoutes registers the owner-only pin/unpin/reorder
+// actions alongside the rest of this package's org-scoped routes, under the
+// same "/org/{username}" group and context.OrgAssignment() middleware that
+// org.Home resolves its org from (ctx.Params(":username")), so both share
+// one consistent org route group instead of each mounting its own.
+func RegisterOrgPinnedRepoRoutes(m *web.Router) {
+	m.Group("/org/{username}", func() {
+		m.Group("/pinned", func() {
+			m.Post("/reorder", org.ReorderPinnedRepos)
+			m.Group("/{repo}", func() {
+				m.Post("", org.PinRepo)
+				m.Delete("", org.UnpinRepo)
+			})
+		}, reqSignIn)
+	}, context.OrgAssignment())
+}